@@ -0,0 +1,191 @@
+// Package bdf parses Glyph Bitmap Distribution Format (BDF) font files and
+// renders them onto in-memory images, for printing Unicode text on ESC/POS
+// printers whose built-in fonts don't cover the required codepage.
+package bdf
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Glyph holds one character's bitmap and advance metrics, as parsed from a
+// STARTCHAR/ENDCHAR block of a BDF font file.
+type Glyph struct {
+	Encoding      rune
+	Width, Height int // BBX width/height in pixels
+	XOff, YOff    int // BBX offset of the bitmap from the glyph origin
+	DWidth        int // horizontal advance in pixels
+
+	// Bitmap holds Height rows of ceil(Width/8) bytes each, packed MSB-first.
+	Bitmap []byte
+}
+
+// Font is a bitmap font parsed from a BDF file.
+type Font struct {
+	Name            string
+	Ascent, Descent int
+
+	glyphs map[rune]*Glyph
+}
+
+// Parse reads a BDF font from r.
+func Parse(r io.Reader) (*Font, error) {
+	font := &Font{glyphs: make(map[rune]*Glyph)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var cur *Glyph
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		keyword := fields[0]
+
+		switch keyword {
+		case "FONT":
+			font.Name = strings.TrimSpace(strings.TrimPrefix(line, keyword))
+
+		case "FONT_ASCENT":
+			if len(fields) >= 2 {
+				font.Ascent, _ = strconv.Atoi(fields[1])
+			}
+
+		case "FONT_DESCENT":
+			if len(fields) >= 2 {
+				font.Descent, _ = strconv.Atoi(fields[1])
+			}
+
+		case "STARTCHAR":
+			cur = &Glyph{Encoding: -1}
+
+		case "ENCODING":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("bdf: malformed ENCODING line %q", line)
+			}
+			code, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("bdf: invalid ENCODING %q: %w", line, err)
+			}
+			if cur != nil {
+				cur.Encoding = rune(code)
+			}
+
+		case "DWIDTH":
+			if cur != nil && len(fields) >= 2 {
+				cur.DWidth, _ = strconv.Atoi(fields[1])
+			}
+
+		case "BBX":
+			if cur != nil && len(fields) >= 5 {
+				cur.Width, _ = strconv.Atoi(fields[1])
+				cur.Height, _ = strconv.Atoi(fields[2])
+				cur.XOff, _ = strconv.Atoi(fields[3])
+				cur.YOff, _ = strconv.Atoi(fields[4])
+			}
+
+		case "BITMAP":
+			if cur == nil {
+				continue
+			}
+			stride := (cur.Width + 7) / 8
+			cur.Bitmap = make([]byte, stride*cur.Height)
+			for row := 0; row < cur.Height && scanner.Scan(); row++ {
+				hexRow := strings.TrimSpace(scanner.Text())
+				raw, err := hex.DecodeString(padHex(hexRow, stride))
+				if err != nil {
+					return nil, fmt.Errorf("bdf: invalid BITMAP row %q: %w", hexRow, err)
+				}
+				copy(cur.Bitmap[row*stride:], raw[:stride])
+			}
+
+		case "ENDCHAR":
+			if cur != nil && cur.Encoding >= 0 {
+				font.glyphs[cur.Encoding] = cur
+			}
+			cur = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return font, nil
+}
+
+// padHex right-pads or truncates s to exactly stride*2 hex digits.
+func padHex(s string, stride int) string {
+	want := stride * 2
+	if len(s) < want {
+		s += strings.Repeat("0", want-len(s))
+	}
+	return s[:want]
+}
+
+// Glyph returns the glyph for c, if the font has one.
+func (f *Font) Glyph(c rune) (*Glyph, bool) {
+	g, ok := f.glyphs[c]
+	return g, ok
+}
+
+// Advance returns the total pixel width of s as rendered by f. Characters the
+// font has no glyph for are skipped.
+func (f *Font) Advance(s string) int {
+	width := 0
+	for _, c := range s {
+		if g, ok := f.glyphs[c]; ok {
+			width += g.DWidth
+		}
+	}
+	return width
+}
+
+// LineHeight returns the font's recommended line spacing in pixels.
+func (f *Font) LineHeight() int {
+	return f.Ascent + f.Descent
+}
+
+// DrawString draws s onto dst in black, with the left end of its baseline at pt.
+// Characters the font has no glyph for are skipped; unset bits are left untouched.
+func (f *Font) DrawString(dst draw.Image, pt image.Point, s string) {
+	x := pt.X
+	for _, c := range s {
+		g, ok := f.glyphs[c]
+		if !ok {
+			continue
+		}
+		drawGlyph(dst, g, x, pt.Y)
+		x += g.DWidth
+	}
+}
+
+// drawGlyph plots g's set bits onto dst in black, with its baseline-left origin at (x, y).
+func drawGlyph(dst draw.Image, g *Glyph, x, y int) {
+	if g.Width <= 0 || g.Height <= 0 {
+		return
+	}
+
+	stride := (g.Width + 7) / 8
+	top := y - g.YOff - (g.Height - 1)
+
+	for row := 0; row < g.Height; row++ {
+		imgY := top + row
+		for col := 0; col < g.Width; col++ {
+			b := g.Bitmap[row*stride+col/8]
+			if b&(0x80>>uint(col%8)) == 0 {
+				continue
+			}
+			dst.Set(x+g.XOff+col, imgY, color.Black)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package bdf
+
+import (
+	"strings"
+	"testing"
+)
+
+const minimalFont = `STARTFONT 2.1
+FONT -test-
+SIZE 8 75 75
+FONT_ASCENT 6
+FONT_DESCENT 2
+CHARS 1
+STARTCHAR A
+ENCODING 65
+SWIDTH 500 0
+DWIDTH 8 0
+BBX 8 8 0 0
+BITMAP
+FF
+81
+81
+81
+81
+81
+81
+FF
+ENDCHAR
+ENDFONT
+`
+
+func TestParse(t *testing.T) {
+	font, err := Parse(strings.NewReader(minimalFont))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if font.Name != "-test-" {
+		t.Errorf("Name = %q, want %q", font.Name, "-test-")
+	}
+	if font.Ascent != 6 || font.Descent != 2 {
+		t.Errorf("Ascent/Descent = %d/%d, want 6/2", font.Ascent, font.Descent)
+	}
+
+	g, ok := font.Glyph('A')
+	if !ok {
+		t.Fatal("Glyph('A') not found")
+	}
+	if g.Width != 8 || g.Height != 8 || g.DWidth != 8 {
+		t.Errorf("glyph metrics = %dx%d dwidth=%d, want 8x8 dwidth=8", g.Width, g.Height, g.DWidth)
+	}
+	want := []byte{0xFF, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0xFF}
+	if string(g.Bitmap) != string(want) {
+		t.Errorf("Bitmap = % X, want % X", g.Bitmap, want)
+	}
+}
+
+// Truncated FONT_ASCENT/FONT_DESCENT lines (as found in corrupt or
+// partially-downloaded BDF files) must not panic indexing fields[1]; the
+// value is simply left at its zero default.
+func TestParseTruncatedOptionalFields(t *testing.T) {
+	src := "STARTFONT 2.1\nFONT -test-\nFONT_ASCENT\nFONT_DESCENT\nENDFONT\n"
+	font, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if font.Ascent != 0 || font.Descent != 0 {
+		t.Errorf("Ascent/Descent = %d/%d, want 0/0", font.Ascent, font.Descent)
+	}
+}
+
+// A truncated ENCODING line has no integer to recover, so it must be reported
+// as an error rather than panicking or silently leaving the glyph unencoded.
+func TestParseTruncatedEncoding(t *testing.T) {
+	src := "STARTFONT 2.1\nFONT -test-\nSTARTCHAR A\nENCODING\nENDCHAR\nENDFONT\n"
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Error("Parse with truncated ENCODING line: want error, got nil")
+	}
+}
@@ -0,0 +1,288 @@
+// Package canvas provides a small vector graphics context — move/line/curve/arc
+// path building, stroking and filling — that rasterizes onto a fixed-width
+// monochrome image sized to a printer's dot width, for printing ruled tables,
+// boxes and mixed text/graphics pages via Flush.
+package canvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"github.com/peter-mount/escpos"
+	"github.com/peter-mount/escpos/bdf"
+)
+
+// flatness is the maximum allowed deviation, in pixels, between a flattened
+// curve and its true path, per the standard de Casteljau subdivision test.
+const flatness = 0.5
+
+// Point is a 2D coordinate in the canvas's pixel space.
+type Point struct {
+	X, Y float64
+}
+
+// GraphicContext is a vector canvas that accumulates a path via MoveTo/LineTo/
+// QuadCurveTo/CubicCurveTo/Arc, then rasterizes it onto a monochrome backing
+// image via Stroke or Fill.
+type GraphicContext struct {
+	img    *image.Gray
+	width  int
+	height int
+
+	lineWidth float64
+
+	current  Point
+	cur      []Point
+	subpaths [][]Point
+}
+
+// New creates a canvas with a white backing image of the given pixel size,
+// typically the printer's dot width (e.g. 576 for an 80mm head).
+func New(width, height int) *GraphicContext {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	return &GraphicContext{img: img, width: width, height: height, lineWidth: 1}
+}
+
+// SetLineWidth sets the pixel width used by subsequent calls to Stroke.
+func (gc *GraphicContext) SetLineWidth(w float64) {
+	gc.lineWidth = w
+}
+
+// MoveTo starts a new subpath at (x, y).
+func (gc *GraphicContext) MoveTo(x, y float64) {
+	gc.finishCurrent()
+	gc.cur = []Point{{x, y}}
+	gc.current = Point{x, y}
+}
+
+// LineTo appends a straight segment to the current subpath.
+func (gc *GraphicContext) LineTo(x, y float64) {
+	if len(gc.cur) == 0 {
+		gc.cur = []Point{gc.current}
+	}
+	gc.cur = append(gc.cur, Point{x, y})
+	gc.current = Point{x, y}
+}
+
+// QuadCurveTo appends a quadratic Bezier curve, flattened into line segments
+// by recursive subdivision until each segment's deviation from its chord is
+// within flatness.
+func (gc *GraphicContext) QuadCurveTo(cx, cy, x, y float64) {
+	if len(gc.cur) == 0 {
+		gc.cur = []Point{gc.current}
+	}
+	gc.flattenQuad(gc.current, Point{cx, cy}, Point{x, y})
+	gc.current = Point{x, y}
+}
+
+func (gc *GraphicContext) flattenQuad(p0, p1, p2 Point) {
+	if pointLineDist(p1, p0, p2) <= flatness {
+		gc.cur = append(gc.cur, p2)
+		return
+	}
+
+	p01 := mid(p0, p1)
+	p12 := mid(p1, p2)
+	p012 := mid(p01, p12)
+
+	gc.flattenQuad(p0, p01, p012)
+	gc.flattenQuad(p012, p12, p2)
+}
+
+// CubicCurveTo appends a cubic Bezier curve, flattened into line segments by
+// recursive subdivision until each control point's deviation from the chord
+// is within flatness.
+func (gc *GraphicContext) CubicCurveTo(c1x, c1y, c2x, c2y, x, y float64) {
+	if len(gc.cur) == 0 {
+		gc.cur = []Point{gc.current}
+	}
+	gc.flattenCubic(gc.current, Point{c1x, c1y}, Point{c2x, c2y}, Point{x, y})
+	gc.current = Point{x, y}
+}
+
+func (gc *GraphicContext) flattenCubic(p0, p1, p2, p3 Point) {
+	if pointLineDist(p1, p0, p3) <= flatness && pointLineDist(p2, p0, p3) <= flatness {
+		gc.cur = append(gc.cur, p3)
+		return
+	}
+
+	p01 := mid(p0, p1)
+	p12 := mid(p1, p2)
+	p23 := mid(p2, p3)
+	p012 := mid(p01, p12)
+	p123 := mid(p12, p23)
+	p0123 := mid(p012, p123)
+
+	gc.flattenCubic(p0, p01, p012, p0123)
+	gc.flattenCubic(p0123, p123, p23, p3)
+}
+
+// Arc appends a circular arc centred at (cx, cy), from startAngle to endAngle
+// (radians), flattened into line segments sized so the sagitta stays within
+// flatness.
+func (gc *GraphicContext) Arc(cx, cy, radius, startAngle, endAngle float64) {
+	if len(gc.cur) == 0 {
+		gc.cur = []Point{{cx + radius*math.Cos(startAngle), cy + radius*math.Sin(startAngle)}}
+		gc.current = gc.cur[0]
+	}
+
+	step := math.Pi / 18 // 10 degree fallback for degenerate radii
+	if radius > flatness/2 {
+		if theta := 2 * math.Acos(1-flatness/radius); theta > 0 {
+			step = theta
+		}
+	}
+
+	steps := int(math.Ceil(math.Abs(endAngle-startAngle) / step))
+	if steps < 1 {
+		steps = 1
+	}
+
+	for i := 1; i <= steps; i++ {
+		a := startAngle + (endAngle-startAngle)*float64(i)/float64(steps)
+		gc.cur = append(gc.cur, Point{cx + radius*math.Cos(a), cy + radius*math.Sin(a)})
+	}
+	gc.current = gc.cur[len(gc.cur)-1]
+}
+
+// Close connects the current subpath's end back to its start.
+func (gc *GraphicContext) Close() {
+	if len(gc.cur) > 1 {
+		gc.cur = append(gc.cur, gc.cur[0])
+		gc.current = gc.cur[len(gc.cur)-1]
+	}
+}
+
+// DrawString draws s in the given font with its baseline-left origin at pt.
+func (gc *GraphicContext) DrawString(font *bdf.Font, pt Point, s string) {
+	font.DrawString(gc.img, image.Pt(int(pt.X), int(pt.Y)), s)
+}
+
+// Stroke rasterizes every subpath's segments as filled quads of SetLineWidth
+// thickness, then clears the path.
+func (gc *GraphicContext) Stroke() {
+	gc.finishCurrent()
+	for _, sp := range gc.subpaths {
+		for i := 0; i+1 < len(sp); i++ {
+			gc.strokeSegment(sp[i], sp[i+1])
+		}
+	}
+	gc.subpaths = nil
+}
+
+func (gc *GraphicContext) strokeSegment(a, b Point) {
+	half := gc.lineWidth / 2
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		gc.setPixel(int(math.Round(a.X)), int(math.Round(a.Y)))
+		return
+	}
+
+	nx, ny := -dy/length*half, dx/length*half
+
+	gc.scanFillPolygon([]Point{
+		{a.X + nx, a.Y + ny},
+		{b.X + nx, b.Y + ny},
+		{b.X - nx, b.Y - ny},
+		{a.X - nx, a.Y - ny},
+	})
+}
+
+// Fill rasterizes every subpath as a closed polygon using an even-odd
+// scanline fill, then clears the path.
+func (gc *GraphicContext) Fill() {
+	gc.finishCurrent()
+	for _, sp := range gc.subpaths {
+		gc.scanFillPolygon(sp)
+	}
+	gc.subpaths = nil
+}
+
+func (gc *GraphicContext) finishCurrent() {
+	if len(gc.cur) > 0 {
+		gc.subpaths = append(gc.subpaths, gc.cur)
+		gc.cur = nil
+	}
+}
+
+// scanFillPolygon fills a closed polygon using an even-odd scanline test,
+// sampling each row at its vertical midpoint.
+func (gc *GraphicContext) scanFillPolygon(pts []Point) {
+	if len(pts) < 3 {
+		return
+	}
+
+	minY, maxY := pts[0].Y, pts[0].Y
+	for _, p := range pts {
+		if p.Y < minY {
+			minY = p.Y
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+
+	y0 := int(math.Floor(minY))
+	y1 := int(math.Ceil(maxY))
+	if y0 < 0 {
+		y0 = 0
+	}
+	if y1 >= gc.height {
+		y1 = gc.height - 1
+	}
+
+	n := len(pts)
+	for y := y0; y <= y1; y++ {
+		yc := float64(y) + 0.5
+
+		var xs []float64
+		for i := 0; i < n; i++ {
+			p1, p2 := pts[i], pts[(i+1)%n]
+			if (p1.Y <= yc && p2.Y > yc) || (p2.Y <= yc && p1.Y > yc) {
+				t := (yc - p1.Y) / (p2.Y - p1.Y)
+				xs = append(xs, p1.X+t*(p2.X-p1.X))
+			}
+		}
+		sort.Float64s(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			x0 := int(math.Round(xs[i]))
+			x1 := int(math.Round(xs[i+1]))
+			for x := x0; x < x1; x++ {
+				gc.setPixel(x, y)
+			}
+		}
+	}
+}
+
+func (gc *GraphicContext) setPixel(x, y int) {
+	if x < 0 || y < 0 || x >= gc.width || y >= gc.height {
+		return
+	}
+	gc.img.SetGray(x, y, color.Gray{Y: 0})
+}
+
+func mid(a, b Point) Point {
+	return Point{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+}
+
+// pointLineDist returns the perpendicular distance from p to the infinite
+// line through a and b.
+func pointLineDist(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	return math.Abs(dy*p.X-dx*p.Y+b.X*a.Y-b.Y*a.X) / math.Hypot(dx, dy)
+}
+
+// Flush prints the canvas's backing image via e's raster image path.
+func (gc *GraphicContext) Flush(e *escpos.Escpos) *escpos.Escpos {
+	return e.PrintImage(gc.img, escpos.ImageOptions{})
+}
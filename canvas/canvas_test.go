@@ -0,0 +1,150 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+// quadBezier evaluates the true quadratic Bezier curve at parameter t, for
+// comparison against the flattened polyline.
+func quadBezier(p0, p1, p2 Point, t float64) Point {
+	u := 1 - t
+	return Point{
+		X: u*u*p0.X + 2*u*t*p1.X + t*t*p2.X,
+		Y: u*u*p0.Y + 2*u*t*p1.Y + t*t*p2.Y,
+	}
+}
+
+// distToPolyline returns the shortest distance from p to any segment of the
+// polyline pts.
+func distToPolyline(p Point, pts []Point) float64 {
+	best := math.Inf(1)
+	for i := 0; i+1 < len(pts); i++ {
+		a, b := pts[i], pts[i+1]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		t := 0.0
+		if l2 := dx*dx + dy*dy; l2 > 0 {
+			t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/l2))
+		}
+		if d := math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy)); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func TestQuadCurveToFlatteningTolerance(t *testing.T) {
+	gc := New(100, 100)
+	p0 := Point{0, 0}
+	p1 := Point{50, 100}
+	p2 := Point{100, 0}
+
+	gc.MoveTo(p0.X, p0.Y)
+	gc.QuadCurveTo(p1.X, p1.Y, p2.X, p2.Y)
+	gc.finishCurrent()
+
+	if len(gc.subpaths) != 1 {
+		t.Fatalf("subpaths = %d, want 1", len(gc.subpaths))
+	}
+	poly := gc.subpaths[0]
+
+	if got := poly[0]; got != p0 {
+		t.Errorf("first point = %v, want %v", got, p0)
+	}
+	if got := poly[len(poly)-1]; got != p2 {
+		t.Errorf("last point = %v, want %v", got, p2)
+	}
+
+	// A tight tolerance margin: every point actually on the curve must lie
+	// close to the flattened polyline.
+	const margin = flatness * 2
+	for i := 0; i <= 100; i++ {
+		pt := quadBezier(p0, p1, p2, float64(i)/100)
+		if d := distToPolyline(pt, poly); d > margin {
+			t.Errorf("t=%.2f: curve point %v is %.3f px from polyline, want <= %.3f", float64(i)/100, pt, d, margin)
+		}
+	}
+}
+
+func TestCubicCurveToFlatteningTolerance(t *testing.T) {
+	gc := New(100, 100)
+	p0 := Point{0, 0}
+	c1 := Point{0, 100}
+	c2 := Point{100, 100}
+	p3 := Point{100, 0}
+
+	gc.MoveTo(p0.X, p0.Y)
+	gc.CubicCurveTo(c1.X, c1.Y, c2.X, c2.Y, p3.X, p3.Y)
+	gc.finishCurrent()
+
+	if len(gc.subpaths) != 1 {
+		t.Fatalf("subpaths = %d, want 1", len(gc.subpaths))
+	}
+	poly := gc.subpaths[0]
+
+	if got := poly[0]; got != p0 {
+		t.Errorf("first point = %v, want %v", got, p0)
+	}
+	if got := poly[len(poly)-1]; got != p3 {
+		t.Errorf("last point = %v, want %v", got, p3)
+	}
+
+	const margin = flatness * 2
+	for i := 0; i <= 100; i++ {
+		tt := float64(i) / 100
+		u := 1 - tt
+		pt := Point{
+			X: u*u*u*p0.X + 3*u*u*tt*c1.X + 3*u*tt*tt*c2.X + tt*tt*tt*p3.X,
+			Y: u*u*u*p0.Y + 3*u*u*tt*c1.Y + 3*u*tt*tt*c2.Y + tt*tt*tt*p3.Y,
+		}
+		if d := distToPolyline(pt, poly); d > margin {
+			t.Errorf("t=%.2f: curve point %v is %.3f px from polyline, want <= %.3f", tt, pt, d, margin)
+		}
+	}
+}
+
+func TestFillRectangle(t *testing.T) {
+	gc := New(10, 10)
+	gc.MoveTo(2, 2)
+	gc.LineTo(8, 2)
+	gc.LineTo(8, 8)
+	gc.LineTo(2, 8)
+	gc.Close()
+	gc.Fill()
+
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			inside := x >= 2 && x < 8 && y >= 2 && y < 8
+			got := gc.img.GrayAt(x, y).Y
+			switch {
+			case inside && got != 0:
+				t.Errorf("(%d,%d) inside fill: gray = %d, want 0 (black)", x, y, got)
+			case !inside && got != 0xff:
+				t.Errorf("(%d,%d) outside fill: gray = %d, want 255 (white)", x, y, got)
+			}
+		}
+	}
+}
+
+func TestStrokeHorizontalLine(t *testing.T) {
+	gc := New(10, 10)
+	gc.SetLineWidth(2)
+	gc.MoveTo(2, 5)
+	gc.LineTo(7, 5)
+	gc.Stroke()
+
+	// The 2px-wide stroke of a horizontal line at y=5 should blacken rows 4-5
+	// across x=2..6, and leave the rest of the canvas white.
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			inside := x >= 2 && x < 7 && (y == 4 || y == 5)
+			got := gc.img.GrayAt(x, y).Y
+			switch {
+			case inside && got != 0:
+				t.Errorf("(%d,%d) on stroke: gray = %d, want 0 (black)", x, y, got)
+			case !inside && got != 0xff:
+				t.Errorf("(%d,%d) off stroke: gray = %d, want 255 (white)", x, y, got)
+			}
+		}
+	}
+}
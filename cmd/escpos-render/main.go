@@ -0,0 +1,68 @@
+// Command escpos-render reads a receipt template file and prints it to an
+// IPP printer, so non-Go users can drive the printer declaratively.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/peter-mount/escpos"
+	"github.com/peter-mount/escpos/template"
+	"github.com/phin1x/go-ipp"
+)
+
+var (
+	host    = flag.String("h", "localhost", "IPP Host")
+	port    = flag.Int("p", 631, "IPP Port")
+	printer = flag.String("d", "", "IPP Printer Name")
+	user    = flag.String("user", "", "IPP Username")
+	pass    = flag.String("pass", "", "IPP Password")
+	useTls  = flag.Bool("tls", true, "Use TLS")
+	jobName = flag.String("j", "escpos-render", "Job name")
+	file    = flag.String("f", "", "Template file to render")
+)
+
+func main() {
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("missing -f template file")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal("Failed to open template: ", err)
+	}
+	defer f.Close()
+
+	p := escpos.NewBuffer().Init()
+
+	fsys := os.DirFS(filepath.Dir(*file))
+	if err := template.Render(p, fsys, f); err != nil {
+		log.Fatal("Failed to render template: ", err)
+	}
+
+	p.FormfeedN(2).Cut().End()
+
+	client := ipp.NewIPPClient(*host, *port, *user, *pass, *useTls)
+
+	buffer := p.Buffer()
+
+	doc := ipp.Document{
+		Document: buffer,
+		Name:     *jobName,
+		Size:     buffer.Len(),
+		MimeType: ipp.MimeTypeOctetStream,
+	}
+
+	jobAttributes := make(map[string]interface{})
+	jobAttributes[ipp.AttributeJobName] = *jobName
+
+	jobID, err := client.PrintJob(doc, *printer, jobAttributes)
+	if err != nil {
+		log.Fatal("Failed to print: ", err)
+	}
+	log.Println("Submitted job", jobID)
+}
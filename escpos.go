@@ -49,6 +49,12 @@ func textReplace(data string) string {
 	return data
 }
 
+// TextReplace expands the XML character references used by templates (e.g.
+// "&amp;", "&#10;") into their literal characters.
+func TextReplace(data string) string {
+	return textReplace(data)
+}
+
 type Escpos struct {
 	dst             io.ReadWriter
 	buffer          bytes.Buffer
@@ -328,7 +334,11 @@ func (e *Escpos) SetLang(lang string) *Escpos {
 	return e.Writef("\x1BR%c", l)
 }
 
-// do a block of text
+// Text writes a block of text, applying the style params WriteNode supports.
+//
+// Deprecated: use the template package to render styled text from a parsed
+// receipt document; WriteNode and its param-map dialect are kept only for
+// source compatibility.
 func (e *Escpos) Text(params map[string]string, data string) *Escpos {
 
 	// send alignment to printer
@@ -508,7 +518,12 @@ func (e *Escpos) gSend(m byte, fn byte, data []byte) *Escpos {
 		WriteRaw(data)
 }
 
-// write an image
+// Image writes a base64-encoded raster image, addressed by the param-map
+// dialect WriteNode supports.
+//
+// Deprecated: use template.Renderer's <image src="..."/> (backed by
+// PrintImage) instead; WriteNode and its param-map dialect are kept only for
+// source compatibility.
 func (e *Escpos) Image(params map[string]string, data string) *Escpos {
 	// send alignment to printer
 	if align, ok := params["align"]; ok {
@@ -568,7 +583,12 @@ func (e *Escpos) Image(params map[string]string, data string) *Escpos {
 
 }
 
-// write a "node" to the printer
+// WriteNode writes a single named node (text/feed/cut/pulse/image) to the
+// printer, dispatching on a flat param map rather than a parsed document.
+//
+// Deprecated: use the template package instead, which parses a full receipt
+// document into a node tree and renders it with style inheritance; WriteNode
+// has no document-level parser and cannot express nesting.
 func (e *Escpos) WriteNode(name string, params map[string]string, data string) *Escpos {
 	switch name {
 	case "text":
@@ -0,0 +1,219 @@
+package escpos
+
+import (
+	"image"
+	"image/color"
+)
+
+// ImageThreshold selects how PrintImage converts a colour image to 1-bit monochrome.
+type ImageThreshold int
+
+const (
+	// ThresholdSimple sets a pixel black if its luminance falls below Level.
+	ThresholdSimple ImageThreshold = iota
+	// ThresholdFloydSteinberg applies Floyd–Steinberg error diffusion dithering.
+	ThresholdFloydSteinberg
+)
+
+// ImageOptions controls how PrintImage converts and emits an image.Image.
+type ImageOptions struct {
+	// Align is passed to SetAlign before the image is sent. Empty leaves the current alignment.
+	Align string
+
+	// Threshold selects the monochrome conversion method.
+	Threshold ImageThreshold
+
+	// Level is the luminance cut-off (0-255, black is 0) used to decide if a pixel is
+	// printed. Defaults to 127 when left at zero.
+	Level uint8
+
+	// DoubleWidth and DoubleHeight set the raster scale flags, printing the image at
+	// double size along that axis.
+	DoubleWidth  bool
+	DoubleHeight bool
+}
+
+// maxRasterBufferBytes is a conservative estimate of a printer's raster line buffer.
+// Bitmaps that would exceed it are split into horizontal bands and sent via the
+// GS ( L graphics command instead of a single GS v 0 raster command.
+const maxRasterBufferBytes = 8192
+
+// rasterBitmap is a packed, MSB-first 1-bit monochrome image ready for the ESC/POS
+// raster commands. A set bit prints a black dot.
+type rasterBitmap struct {
+	width, height int
+	stride        int // bytes per row
+	data          []byte
+}
+
+func newRasterBitmap(width, height int) *rasterBitmap {
+	stride := (width + 7) / 8
+	return &rasterBitmap{width: width, height: height, stride: stride, data: make([]byte, stride*height)}
+}
+
+func (r *rasterBitmap) set(x, y int) {
+	r.data[y*r.stride+x/8] |= 0x80 >> uint(x%8)
+}
+
+// band returns the sub-bitmap covering [y0, y0+lines) rows, sharing the backing array.
+func (r *rasterBitmap) band(y0, lines int) *rasterBitmap {
+	return &rasterBitmap{
+		width:  r.width,
+		height: lines,
+		stride: r.stride,
+		data:   r.data[y0*r.stride : (y0+lines)*r.stride],
+	}
+}
+
+// PrintImage converts img to a 1-bit monochrome bitmap and prints it using the
+// ESC/POS raster bit-image command. Images too tall to fit a single raster
+// command's line buffer are split into horizontal bands and sent via the
+// GS ( L graphics command instead.
+func (e *Escpos) PrintImage(img image.Image, opts ImageOptions) *Escpos {
+	if opts.Align != "" {
+		e.SetAlign(opts.Align)
+	}
+
+	bits := rasterize(img, opts)
+	if bits.width <= 0 || bits.height <= 0 {
+		return e
+	}
+
+	var m byte
+	if opts.DoubleWidth {
+		m |= 1
+	}
+	if opts.DoubleHeight {
+		m |= 2
+	}
+
+	if bits.stride*bits.height <= maxRasterBufferBytes {
+		return e.sendRasterImage(m, bits)
+	}
+
+	linesPerBand := maxRasterBufferBytes / bits.stride
+	if linesPerBand < 1 {
+		linesPerBand = 1
+	}
+
+	for y := 0; y < bits.height; y += linesPerBand {
+		lines := linesPerBand
+		if y+lines > bits.height {
+			lines = bits.height - y
+		}
+		e.sendGraphicsBand(m, bits.band(y, lines))
+	}
+	return e
+}
+
+// rasterize converts img to a packed 1-bit monochrome bitmap, using either simple
+// thresholding or Floyd–Steinberg error diffusion dithering.
+func rasterize(img image.Image, opts ImageOptions) *rasterBitmap {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	r := newRasterBitmap(width, height)
+
+	level := float64(opts.Level)
+	if opts.Level == 0 {
+		level = 127
+	}
+
+	if opts.Threshold != ThresholdFloydSteinberg {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if luminance(img.At(bounds.Min.X+x, bounds.Min.Y+y)) < level {
+					r.set(x, y)
+				}
+			}
+		}
+		return r
+	}
+
+	// error diffusion buffer holding each pixel's running luminance as it is
+	// adjusted by neighbours already visited; writes to the row below are
+	// guarded by the y+1 < height check in the loop below
+	gray := make([][]float64, height)
+	for y := range gray {
+		gray[y] = make([]float64, width)
+		for x := 0; x < width; x++ {
+			gray[y][x] = luminance(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			old := gray[y][x]
+			new := 255.0
+			if old < level {
+				new = 0
+				r.set(x, y)
+			}
+
+			errDiff := old - new
+			if x+1 < width {
+				gray[y][x+1] += errDiff * 7 / 16
+			}
+			if y+1 < height {
+				if x-1 >= 0 {
+					gray[y+1][x-1] += errDiff * 3 / 16
+				}
+				gray[y+1][x] += errDiff * 5 / 16
+				if x+1 < width {
+					gray[y+1][x+1] += errDiff * 1 / 16
+				}
+			}
+		}
+	}
+	return r
+}
+
+// luminance returns the perceived brightness of c in the range 0-255, black is 0.
+func luminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	return 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(b>>8)
+}
+
+// sendRasterImage emits a single GS v 0 raster bit-image command.
+func (e *Escpos) sendRasterImage(m byte, b *rasterBitmap) *Escpos {
+	xL, xH := byte(b.stride%256), byte(b.stride/256)
+	yL, yH := byte(b.height%256), byte(b.height/256)
+
+	return e.Write("\x1dv0").
+		WriteRaw([]byte{m, xL, xH, yL, yH}).
+		WriteRaw(b.data)
+}
+
+// sendGraphicsBand emits one horizontal band of a large image via the
+// GS ( L "store raster graphics data" / "print graphics data" commands.
+func (e *Escpos) sendGraphicsBand(m byte, b *rasterBitmap) *Escpos {
+	bx, by := byte(1), byte(1)
+	if m&1 != 0 {
+		bx = 2
+	}
+	if m&2 != 0 {
+		by = 2
+	}
+
+	xL, xH := byte(b.stride%256), byte(b.stride/256)
+	yL, yH := byte(b.height%256), byte(b.height/256)
+
+	header := []byte{byte('0'), bx, by, byte('1'), xL, xH, yL, yH}
+	data := append(append([]byte{}, header...), b.data...)
+
+	return e.gsSend(byte('0'), byte('p'), data).
+		gsSend(byte('0'), byte('2'), []byte{})
+}
+
+// gsSend sends a GS ( L graphics command: m 0x30 with fn 'p' stores raster
+// graphics data, m 0x30 with fn '2' prints the stored data. Unlike the older
+// gSend helper (which emits ESC ( L and is used by Image), this is the
+// correct GS-prefixed introducer the "store/print graphics data" commands
+// require.
+func (e *Escpos) gsSend(m byte, fn byte, data []byte) *Escpos {
+	l := len(data) + 2
+
+	return e.Write("\x1d(L").
+		WriteRaw([]byte{byte(l % 256), byte(l / 256), m, fn}).
+		WriteRaw(data)
+}
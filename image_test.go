@@ -0,0 +1,66 @@
+package escpos
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func solidImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestPrintImageSmallUsesRasterCommand(t *testing.T) {
+	e := NewBuffer()
+	e.Buffer().Reset()
+
+	img := solidImage(8, 1, color.Black)
+	e.PrintImage(img, ImageOptions{})
+
+	got := e.Buffer().Bytes()
+	want := []byte{0x1d, 'v', '0', 0, 1, 0, 1, 0, 0xff}
+	if !bytes.Equal(got, want) {
+		t.Errorf("raster command = % X, want % X", got, want)
+	}
+}
+
+// A band-split image must be sent with the GS ( L introducer (0x1D 0x28 0x4C),
+// not the pre-existing ESC ( L sequence used elsewhere in this package.
+func TestPrintImageBandedUsesGSIntroducer(t *testing.T) {
+	e := NewBuffer()
+	e.Buffer().Reset()
+
+	img := solidImage(576, 2000, color.White)
+	e.PrintImage(img, ImageOptions{})
+
+	got := e.Buffer().Bytes()
+	if len(got) < 3 {
+		t.Fatalf("output too short: % X", got)
+	}
+	wantPrefix := []byte{0x1d, '(', 'L'}
+	if !bytes.Equal(got[:3], wantPrefix) {
+		t.Errorf("band introducer = % X, want % X (GS ( L)", got[:3], wantPrefix)
+	}
+}
+
+func TestRasterizeFloydSteinberg(t *testing.T) {
+	img := solidImage(2, 2, color.Black)
+	b := rasterize(img, ImageOptions{Threshold: ThresholdFloydSteinberg})
+
+	if b.width != 2 || b.height != 2 {
+		t.Fatalf("dims = %dx%d, want 2x2", b.width, b.height)
+	}
+	// every pixel is pure black, so every bit should be set regardless of
+	// diffused error
+	want := byte(0xC0) // 0b11000000 - top two bits set (2-pixel-wide row)
+	if b.data[0] != want || b.data[1] != want {
+		t.Errorf("rows = % X, want % X % X", b.data, want, want)
+	}
+}
@@ -0,0 +1,166 @@
+package escpos
+
+// symbol storage cn values for the various two-dimensional code types handled by
+// the GS ( k function family. Each type shares the same fn=80 (store data) and
+// fn=81 (print data) functions, differing only in their setup functions.
+const (
+	symbol2DQR         = byte('1') // 49
+	symbol2DPDF417     = byte('0') // 48
+	symbol2DDataMatrix = byte('6') // 54
+)
+
+// QRModel selects the QR Code symbol model used by QRCode.
+type QRModel int
+
+const (
+	QRModel1 QRModel = iota + 1
+	QRModel2
+	QRModelMicro
+)
+
+// QRErrorCorrection selects the QR Code error correction level used by QRCode.
+type QRErrorCorrection int
+
+const (
+	QRECLevelL QRErrorCorrection = iota
+	QRECLevelM
+	QRECLevelQ
+	QRECLevelH
+)
+
+// QROptions controls how QRCode renders its symbol.
+type QROptions struct {
+	// Model selects the QR Code version family. Zero defaults to QRModel2.
+	Model QRModel
+
+	// ModuleSize is the dot size of each module, 1-16. Zero defaults to 3.
+	ModuleSize byte
+
+	ECLevel QRErrorCorrection
+
+	// Align is passed to SetAlign before the symbol is sent. Empty leaves the
+	// current alignment.
+	Align string
+}
+
+// gs2D emits one GS ( k pL pH cn fn [params] command, the framing shared by all
+// of the printer's two-dimensional symbol functions.
+func (e *Escpos) gs2D(cn byte, fn byte, params []byte) *Escpos {
+	l := len(params) + 2
+
+	return e.Write("\x1d(k").
+		WriteRaw([]byte{byte(l % 256), byte(l / 256), cn, fn}).
+		WriteRaw(params)
+}
+
+// gs2DStoreAndPrint stores data into the symbol's storage area and prints it,
+// via the fn=80/fn=81 functions common to every GS ( k symbol type.
+func (e *Escpos) gs2DStoreAndPrint(cn byte, data string) *Escpos {
+	return e.gs2D(cn, 80, append([]byte{byte('0')}, []byte(data)...)).
+		gs2D(cn, 81, []byte{byte('0')})
+}
+
+// QRCode prints data as a QR Code symbol, via the cn=49 function family:
+// select model (fn=65), module size (fn=67), error correction level (fn=69),
+// then store (fn=80) and print (fn=81) the symbol data.
+func (e *Escpos) QRCode(data string, opts QROptions) *Escpos {
+	if opts.Align != "" {
+		e.SetAlign(opts.Align)
+	}
+
+	model := opts.Model
+	if model == 0 {
+		model = QRModel2
+	}
+	moduleSize := opts.ModuleSize
+	if moduleSize == 0 {
+		moduleSize = 3
+	}
+
+	var n1 byte
+	switch model {
+	case QRModel1:
+		n1 = 49
+	case QRModelMicro:
+		n1 = 51
+	default:
+		n1 = 50
+	}
+
+	e.gs2D(symbol2DQR, 65, []byte{n1, 0}).
+		gs2D(symbol2DQR, 67, []byte{moduleSize}).
+		gs2D(symbol2DQR, 69, []byte{48 + byte(opts.ECLevel)})
+
+	return e.gs2DStoreAndPrint(symbol2DQR, data)
+}
+
+// PDF417Options controls how PDF417Code renders its symbol.
+type PDF417Options struct {
+	// Columns is the number of data columns, 0 lets the printer choose.
+	Columns byte
+	// Rows is the number of rows, 0 lets the printer choose.
+	Rows byte
+	// ModuleWidth is the horizontal dot size per module, 2-8. Zero defaults to 3.
+	ModuleWidth byte
+	// ECLevel is the PDF417 error correction level, 0-8. Zero defaults to 1.
+	ECLevel byte
+	// Truncated emits a truncated (narrower) symbol.
+	Truncated bool
+	Align     string
+}
+
+// PDF417Code prints data as a PDF417 symbol, via the cn=48 function family:
+// columns (fn=65), rows (fn=66), module width (fn=67), error correction level
+// (fn=69) and truncation option (fn=70), then store (fn=80) and print (fn=81).
+func (e *Escpos) PDF417Code(data string, opts PDF417Options) *Escpos {
+	if opts.Align != "" {
+		e.SetAlign(opts.Align)
+	}
+
+	moduleWidth := opts.ModuleWidth
+	if moduleWidth == 0 {
+		moduleWidth = 3
+	}
+	ecLevel := opts.ECLevel
+	if ecLevel == 0 {
+		ecLevel = 1
+	}
+	truncated := byte(0)
+	if opts.Truncated {
+		truncated = 1
+	}
+
+	e.gs2D(symbol2DPDF417, 65, []byte{opts.Columns}).
+		gs2D(symbol2DPDF417, 66, []byte{opts.Rows}).
+		gs2D(symbol2DPDF417, 67, []byte{moduleWidth}).
+		gs2D(symbol2DPDF417, 69, []byte{48 + ecLevel}).
+		gs2D(symbol2DPDF417, 70, []byte{truncated})
+
+	return e.gs2DStoreAndPrint(symbol2DPDF417, data)
+}
+
+// DataMatrixOptions controls how DataMatrixCode renders its symbol.
+type DataMatrixOptions struct {
+	// ModuleSize is the dot size of each module, 2-16. Zero defaults to 3.
+	ModuleSize byte
+	Align      string
+}
+
+// DataMatrixCode prints data as a Data Matrix symbol, via the cn=54 function
+// family: auto symbol type (fn=66), module size (fn=67), then store (fn=80)
+// and print (fn=81) the symbol data.
+func (e *Escpos) DataMatrixCode(data string, opts DataMatrixOptions) *Escpos {
+	if opts.Align != "" {
+		e.SetAlign(opts.Align)
+	}
+
+	moduleSize := opts.ModuleSize
+	if moduleSize == 0 {
+		moduleSize = 3
+	}
+
+	e.gs2D(symbol2DDataMatrix, 66, []byte{0}).
+		gs2D(symbol2DDataMatrix, 67, []byte{moduleSize})
+
+	return e.gs2DStoreAndPrint(symbol2DDataMatrix, data)
+}
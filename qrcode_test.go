@@ -0,0 +1,69 @@
+package escpos
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQRCodeFraming(t *testing.T) {
+	e := NewBuffer()
+	e.Buffer().Reset()
+
+	e.QRCode("hi", QROptions{})
+
+	want := []byte{}
+	want = append(want, gs2DCmd(symbol2DQR, 65, []byte{50, 0})...)
+	want = append(want, gs2DCmd(symbol2DQR, 67, []byte{3})...)
+	want = append(want, gs2DCmd(symbol2DQR, 69, []byte{48})...)
+	want = append(want, gs2DCmd(symbol2DQR, 80, append([]byte{'0'}, "hi"...))...)
+	want = append(want, gs2DCmd(symbol2DQR, 81, []byte{'0'})...)
+
+	if got := e.Buffer().Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("QRCode framing = % X, want % X", got, want)
+	}
+}
+
+func TestPDF417CodeFraming(t *testing.T) {
+	e := NewBuffer()
+	e.Buffer().Reset()
+
+	e.PDF417Code("hi", PDF417Options{})
+
+	want := []byte{}
+	want = append(want, gs2DCmd(symbol2DPDF417, 65, []byte{0})...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 66, []byte{0})...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 67, []byte{3})...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 69, []byte{49})...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 70, []byte{0})...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 80, append([]byte{'0'}, "hi"...))...)
+	want = append(want, gs2DCmd(symbol2DPDF417, 81, []byte{'0'})...)
+
+	if got := e.Buffer().Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("PDF417Code framing = % X, want % X", got, want)
+	}
+}
+
+func TestDataMatrixCodeFraming(t *testing.T) {
+	e := NewBuffer()
+	e.Buffer().Reset()
+
+	e.DataMatrixCode("hi", DataMatrixOptions{})
+
+	want := []byte{}
+	want = append(want, gs2DCmd(symbol2DDataMatrix, 66, []byte{0})...)
+	want = append(want, gs2DCmd(symbol2DDataMatrix, 67, []byte{3})...)
+	want = append(want, gs2DCmd(symbol2DDataMatrix, 80, append([]byte{'0'}, "hi"...))...)
+	want = append(want, gs2DCmd(symbol2DDataMatrix, 81, []byte{'0'})...)
+
+	if got := e.Buffer().Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("DataMatrixCode framing = % X, want % X", got, want)
+	}
+}
+
+// gs2DCmd builds the raw bytes of one GS ( k command, independently of gs2D,
+// so the test fails if the production framing drifts from the GS ( k spec.
+func gs2DCmd(cn, fn byte, params []byte) []byte {
+	l := len(params) + 2
+	cmd := []byte{0x1d, '(', 'k', byte(l % 256), byte(l / 256), cn, fn}
+	return append(cmd, params...)
+}
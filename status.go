@@ -0,0 +1,104 @@
+package escpos
+
+import (
+	"fmt"
+	"io"
+)
+
+// Status reports a printer's real-time state, decoded from the four DLE EOT
+// transmission status responses (or an equivalent Automatic Status Back frame).
+type Status struct {
+	Online           bool
+	CoverOpen        bool
+	PaperEnd         bool
+	PaperNearEnd     bool
+	CutterError      bool
+	RecoverableError bool
+	AutoRecoverable  bool
+}
+
+// decodeStatus decodes the four status bytes returned by DLE EOT n for
+// n=1 (printer status), 2 (offline cause), 3 (error cause) and 4 (paper
+// sensor), in that order. An Automatic Status Back frame uses the same layout.
+func decodeStatus(b [4]byte) Status {
+	printerStatus, offlineCause, errorCause, paperSensor := b[0], b[1], b[2], b[3]
+
+	return Status{
+		Online:           printerStatus&0x08 == 0,
+		CoverOpen:        offlineCause&0x04 != 0,
+		PaperEnd:         offlineCause&0x20 != 0 || paperSensor&0x60 != 0,
+		PaperNearEnd:     paperSensor&0x0C != 0,
+		CutterError:      errorCause&0x08 != 0,
+		RecoverableError: errorCause&0x04 != 0,
+		AutoRecoverable:  errorCause&0x40 != 0,
+	}
+}
+
+// readStatusByte sends "DLE EOT n" and reads back the single response byte.
+func (e *Escpos) readStatusByte(n byte) (byte, error) {
+	e.WriteRaw([]byte{DLE, EOT, n})
+
+	r, ok := e.dst.(io.Reader)
+	if !ok {
+		return 0, fmt.Errorf("escpos: destination does not support reading status")
+	}
+
+	var buf [1]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+// Status reads back the printer's current status via DLE EOT n for n=1..4 and
+// decodes it.
+func (e *Escpos) Status() (Status, error) {
+	var frame [4]byte
+
+	for i := byte(0); i < 4; i++ {
+		b, err := e.readStatusByte(i + 1)
+		if err != nil {
+			return Status{}, err
+		}
+		frame[i] = b
+	}
+
+	return decodeStatus(frame), nil
+}
+
+// SetASB enables or disables Automatic Status Back, where the printer pushes a
+// 4-byte status frame on its own whenever its status changes, via GS a n.
+func (e *Escpos) SetASB(enabled bool) *Escpos {
+	var n byte
+	if enabled {
+		n = 0xFF
+	}
+	return e.WriteRaw([]byte{GS, 'a', n})
+}
+
+// StatusChan starts a goroutine that continuously reads 4-byte Automatic
+// Status Back frames from the printer and decodes them, for callers that have
+// enabled ASB via SetASB. The returned channel is closed once the underlying
+// reader returns an error.
+func (e *Escpos) StatusChan() <-chan Status {
+	ch := make(chan Status)
+
+	r, ok := e.dst.(io.Reader)
+	if !ok {
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		defer close(ch)
+		for {
+			var frame [4]byte
+			if _, err := io.ReadFull(r, frame[:]); err != nil {
+				return
+			}
+			ch <- decodeStatus(frame)
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,78 @@
+package escpos
+
+import "testing"
+
+func TestDecodeStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		b    [4]byte
+		want Status
+	}{
+		{
+			name: "all clear",
+			b:    [4]byte{0x00, 0x00, 0x00, 0x00},
+			want: Status{Online: true},
+		},
+		{
+			name: "offline",
+			b:    [4]byte{0x08, 0x00, 0x00, 0x00},
+			want: Status{Online: false},
+		},
+		{
+			name: "cover open",
+			b:    [4]byte{0x00, 0x04, 0x00, 0x00},
+			want: Status{Online: true, CoverOpen: true},
+		},
+		{
+			name: "paper end via offline cause",
+			b:    [4]byte{0x00, 0x20, 0x00, 0x00},
+			want: Status{Online: true, PaperEnd: true},
+		},
+		{
+			name: "paper end via paper sensor",
+			b:    [4]byte{0x00, 0x00, 0x00, 0x60},
+			want: Status{Online: true, PaperEnd: true},
+		},
+		{
+			name: "paper near end",
+			b:    [4]byte{0x00, 0x00, 0x00, 0x0C},
+			want: Status{Online: true, PaperNearEnd: true},
+		},
+		{
+			name: "cutter error",
+			b:    [4]byte{0x00, 0x00, 0x08, 0x00},
+			want: Status{Online: true, CutterError: true},
+		},
+		{
+			name: "recoverable error",
+			b:    [4]byte{0x00, 0x00, 0x04, 0x00},
+			want: Status{Online: true, RecoverableError: true},
+		},
+		{
+			name: "auto recoverable",
+			b:    [4]byte{0x00, 0x00, 0x40, 0x00},
+			want: Status{Online: true, AutoRecoverable: true},
+		},
+		{
+			name: "all flags combined",
+			b:    [4]byte{0x08, 0x24, 0x4C, 0x6C},
+			want: Status{
+				Online:           false,
+				CoverOpen:        true,
+				PaperEnd:         true,
+				PaperNearEnd:     true,
+				CutterError:      true,
+				RecoverableError: true,
+				AutoRecoverable:  true,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := decodeStatus(c.b); got != c.want {
+				t.Errorf("decodeStatus(% X) = %+v, want %+v", c.b, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Package template parses a small XML dialect describing a receipt layout —
+//
+//	<receipt><text align="center" em="1">Hi</text><barcode type="code128">ABC</barcode>
+//	<qr ec="M">https://...</qr><image src="logo.png"/><cut/></receipt>
+//
+// — into a node tree and renders it against an *escpos.Escpos.
+package template
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/peter-mount/escpos"
+)
+
+// Node is one element of a parsed receipt template.
+type Node struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*Node
+
+	// Parts preserves the document order of character data and child elements,
+	// so mixed content (text interleaved with child elements) renders in the
+	// order it was written rather than all text first.
+	Parts []Part
+}
+
+// Part is one piece of a Node's mixed content: either a text segment (Child
+// nil) or a child element (Text empty).
+type Part struct {
+	Text  string
+	Child *Node
+}
+
+// Parse reads a receipt template document from r into a Node tree.
+func Parse(r io.Reader) (*Node, error) {
+	dec := xml.NewDecoder(r)
+
+	var stack []*Node
+	var root *Node
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Name: t.Name.Local, Attrs: make(map[string]string, len(t.Attr))}
+			for _, a := range t.Attr {
+				n.Attrs[a.Name.Local] = a.Value
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+				parent.Parts = append(parent.Parts, Part{Child: n})
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				top.Text += string(t)
+				top.Parts = append(top.Parts, Part{Text: string(t)})
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("template: empty document")
+	}
+	return root, nil
+}
+
+// Render parses a receipt template document from r and renders it to e,
+// resolving <image src="..."/> against fsys.
+func Render(e *escpos.Escpos, fsys fs.FS, r io.Reader) error {
+	root, err := Parse(r)
+	if err != nil {
+		return err
+	}
+	return (&Renderer{E: e, FS: fsys}).Render(root)
+}
+
+// style is the set of text attributes that nested elements inherit from their
+// parent and restore once their own children have been rendered.
+type style struct {
+	align           string
+	font            string
+	em, ul          bool
+	reverse, rotate bool
+	width, height   uint8
+}
+
+func defaultStyle() style {
+	return style{width: 1, height: 1}
+}
+
+// merge returns the style produced by applying attrs on top of s.
+func (s style) merge(attrs map[string]string) style {
+	if v, ok := attrs["align"]; ok {
+		s.align = v
+	}
+	if v, ok := attrs["font"]; ok {
+		s.font = v
+	}
+	if boolAttr(attrs, "em") {
+		s.em = true
+	}
+	if boolAttr(attrs, "ul") {
+		s.ul = true
+	}
+	if boolAttr(attrs, "reverse") {
+		s.reverse = true
+	}
+	if boolAttr(attrs, "rotate") {
+		s.rotate = true
+	}
+	if v, ok := attrs["width"]; ok {
+		if i, err := strconv.Atoi(v); err == nil {
+			s.width = uint8(i)
+		}
+	}
+	if v, ok := attrs["height"]; ok {
+		if i, err := strconv.Atoi(v); err == nil {
+			s.height = uint8(i)
+		}
+	}
+	if boolAttr(attrs, "dw") {
+		s.width = 2
+	}
+	if boolAttr(attrs, "dh") {
+		s.height = 2
+	}
+	return s
+}
+
+func boolAttr(attrs map[string]string, key string) bool {
+	v, ok := attrs[key]
+	return ok && (v == "true" || v == "1")
+}
+
+// barcodeFormats maps the template's "type" attribute to the format codes
+// understood by Escpos.Barcode.
+var barcodeFormats = map[string]int{
+	"upc-a":   0,
+	"upc-e":   1,
+	"ean13":   2,
+	"jan13":   2,
+	"ean8":    3,
+	"jan8":    3,
+	"code39":  4,
+	"code128": 73,
+}
+
+// Renderer walks a parsed template tree and emits it to E, resolving
+// <image src="..."/> against FS.
+type Renderer struct {
+	E  *escpos.Escpos
+	FS fs.FS
+
+	applied    style
+	hasApplied bool
+}
+
+// Render walks the template tree rooted at n and emits it to r.E.
+func (r *Renderer) Render(n *Node) error {
+	return r.renderNode(n, defaultStyle())
+}
+
+// apply sends the printer commands needed to reach style s, skipping the call
+// entirely if s is identical to the style last applied.
+func (r *Renderer) apply(s style) {
+	if r.hasApplied && r.applied == s {
+		return
+	}
+	r.applied = s
+	r.hasApplied = true
+
+	e := r.E
+
+	align := s.align
+	if align == "" {
+		align = "left"
+	}
+	e.SetAlign(align)
+	e.SetFont(s.font)
+
+	v := uint8(0)
+	if s.em {
+		v = 1
+	}
+	e.SetEmphasize(v)
+
+	v = 0
+	if s.ul {
+		v = 1
+	}
+	e.SetUnderline(v)
+
+	v = 0
+	if s.reverse {
+		v = 1
+	}
+	e.SetReverse(v)
+
+	v = 0
+	if s.rotate {
+		v = 1
+	}
+	e.SetRotate(v)
+
+	width, height := s.width, s.height
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+	e.SetFontSize(width, height)
+}
+
+// renderMixedContent renders n's character data and child elements in the
+// document order recorded in n.Parts, so text interleaved with child
+// elements (e.g. a label before a barcode) prints in the order it was written.
+func (r *Renderer) renderMixedContent(n *Node, s style) error {
+	for _, p := range n.Parts {
+		if p.Child != nil {
+			if err := r.renderNode(p.Child, s); err != nil {
+				return err
+			}
+			continue
+		}
+		// xml.CharData is already entity-unescaped by encoding/xml, so the text
+		// is written as-is rather than run through another round of unescaping.
+		r.E.Write(p.Text)
+	}
+	return nil
+}
+
+func (r *Renderer) renderNode(n *Node, parent style) error {
+	cur := parent.merge(n.Attrs)
+
+	switch n.Name {
+	case "receipt", "group", "text":
+		r.apply(cur)
+		if err := r.renderMixedContent(n, cur); err != nil {
+			return err
+		}
+		r.apply(parent)
+
+	case "barcode":
+		r.apply(cur)
+		format, ok := barcodeFormats[strings.ToLower(n.Attrs["type"])]
+		if !ok {
+			return fmt.Errorf("template: unknown barcode type %q", n.Attrs["type"])
+		}
+		// Escpos.Barcode always centers the symbol itself, so align on <barcode>
+		// has no effect on the barcode. That forced center alignment differs
+		// from what apply() last sent, so the cache must be invalidated or the
+		// next apply() call may be wrongly skipped as a no-op.
+		r.E.Barcode(strings.TrimSpace(n.Text), format)
+		r.hasApplied = false
+		r.apply(parent)
+
+	case "qr":
+		r.apply(cur)
+		opts := escpos.QROptions{Align: cur.align, ECLevel: ecLevel(n.Attrs["ec"])}
+		if v, ok := n.Attrs["size"]; ok {
+			if i, err := strconv.Atoi(v); err == nil {
+				opts.ModuleSize = byte(i)
+			}
+		}
+		r.E.QRCode(strings.TrimSpace(n.Text), opts)
+		r.apply(parent)
+
+	case "pdf417":
+		r.apply(cur)
+		r.E.PDF417Code(strings.TrimSpace(n.Text), escpos.PDF417Options{Align: cur.align})
+		r.apply(parent)
+
+	case "datamatrix":
+		r.apply(cur)
+		r.E.DataMatrixCode(strings.TrimSpace(n.Text), escpos.DataMatrixOptions{Align: cur.align})
+		r.apply(parent)
+
+	case "image":
+		r.apply(cur)
+		if err := r.renderImage(n); err != nil {
+			return err
+		}
+		r.apply(parent)
+
+	case "feed":
+		r.E.Feed(n.Attrs)
+
+	case "cut":
+		r.E.FeedAndCut(n.Attrs)
+
+	case "pulse":
+		r.E.Pulse()
+
+	default:
+		return fmt.Errorf("template: unknown element %q", n.Name)
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderImage(n *Node) error {
+	src, ok := n.Attrs["src"]
+	if !ok {
+		return fmt.Errorf("template: <image> missing src")
+	}
+	if r.FS == nil {
+		return fmt.Errorf("template: no filesystem configured for <image src=%q>", src)
+	}
+
+	f, err := r.FS.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return fmt.Errorf("template: decoding <image src=%q>: %w", src, err)
+	}
+
+	opts := escpos.ImageOptions{}
+	if boolAttr(n.Attrs, "dither") {
+		opts.Threshold = escpos.ThresholdFloydSteinberg
+	}
+
+	r.E.PrintImage(img, opts)
+	return nil
+}
+
+func ecLevel(v string) escpos.QRErrorCorrection {
+	switch strings.ToUpper(v) {
+	case "L":
+		return escpos.QRECLevelL
+	case "Q":
+		return escpos.QRECLevelQ
+	case "H":
+		return escpos.QRECLevelH
+	default:
+		return escpos.QRECLevelM
+	}
+}
@@ -0,0 +1,43 @@
+package template
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/peter-mount/escpos"
+)
+
+// A <barcode> node resets the printer's physical style as a side effect of
+// escpos.Escpos.Barcode (it calls e.reset() and forces center alignment).
+// Render must re-apply the parent style afterwards even when the merged
+// <barcode> style happens to equal the Renderer's cached style, or a
+// following sibling silently inherits Barcode's forced alignment instead of
+// the receipt's declared one.
+func TestRenderBarcodeInvalidatesStyleCache(t *testing.T) {
+	e := escpos.NewBuffer()
+	e.Buffer().Reset()
+
+	doc := `<receipt align="right"><barcode type="code128">ABC</barcode><text>after</text></receipt>`
+	if err := Render(e, nil, strings.NewReader(doc)); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	got := e.Buffer().Bytes()
+
+	// SetAlign("right") is ESC a 0x02; it must appear again after the barcode
+	// is written, re-asserting the receipt's alignment before "after" prints.
+	rightAlign := []byte{0x1b, 'a', 0x02}
+	firstIdx := bytes.Index(got, rightAlign)
+	if firstIdx < 0 {
+		t.Fatalf("no ESC a 0x02 (right align) found in output: % X", got)
+	}
+	secondIdx := bytes.Index(got[firstIdx+len(rightAlign):], rightAlign)
+	if secondIdx < 0 {
+		t.Errorf("right align not re-asserted after <barcode>; output: % X", got)
+	}
+
+	if !bytes.Contains(got, []byte("after")) {
+		t.Errorf("output missing sibling text %q: % X", "after", got)
+	}
+}
@@ -0,0 +1,96 @@
+package escpos
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+
+	"github.com/peter-mount/escpos/bdf"
+)
+
+// TextOptions controls line wrapping, spacing and scale for Escpos.PrintText.
+type TextOptions struct {
+	// Width is the pixel width to wrap lines at. Zero disables wrapping and sizes
+	// the image to the widest rendered line instead.
+	Width int
+
+	// LineSpacing is an extra pixel gap added between wrapped lines, on top of the
+	// font's own ascent+descent.
+	LineSpacing int
+
+	Align        string
+	DoubleWidth  bool
+	DoubleHeight bool
+}
+
+// PrintText composites s using font into an in-memory 1-bit image, wrapping lines
+// to opts.Width, and prints it via the raster image path.
+func (e *Escpos) PrintText(font *bdf.Font, s string, opts TextOptions) *Escpos {
+	lines := wrapText(font, s, opts.Width)
+
+	lineHeight := font.LineHeight() + opts.LineSpacing
+	if lineHeight <= 0 {
+		lineHeight = 1
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		for _, line := range lines {
+			if adv := font.Advance(line); adv > width {
+				width = adv
+			}
+		}
+	}
+	if width <= 0 || len(lines) == 0 {
+		return e
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, lineHeight*len(lines)))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for i, line := range lines {
+		baseline := i*lineHeight + font.Ascent
+		font.DrawString(img, image.Pt(0, baseline), line)
+	}
+
+	return e.PrintImage(img, ImageOptions{
+		Align:        opts.Align,
+		DoubleWidth:  opts.DoubleWidth,
+		DoubleHeight: opts.DoubleHeight,
+	})
+}
+
+// wrapText splits s into lines that fit within width pixels when rendered with font,
+// preserving existing newlines as paragraph breaks. width<=0 disables wrapping.
+// Lines break only between words: a single word wider than width is kept whole
+// on its own line, so that line is wider than width.
+func wrapText(font *bdf.Font, s string, width int) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(s, "\n") {
+		if width <= 0 {
+			lines = append(lines, paragraph)
+			continue
+		}
+
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := words[0]
+		for _, w := range words[1:] {
+			candidate := line + " " + w
+			if font.Advance(candidate) > width {
+				lines = append(lines, line)
+				line = w
+			} else {
+				line = candidate
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}